@@ -0,0 +1,112 @@
+package apidoc
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// paramPattern matches gin's ":name" path parameter syntax.
+var paramPattern = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// BuildSpec generates an OpenAPI 3.0 document describing every route
+// registered on router. albumSchema is referenced by the /albums routes'
+// request and response bodies.
+func BuildSpec(router *gin.Engine, title, version string, albumSchema *Schema) *Document {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: title, Version: version},
+		Paths:   map[string]PathItem{},
+	}
+
+	for _, route := range router.Routes() {
+		path := openAPIPath(route.Path)
+		method := strings.ToLower(route.Method)
+
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[path] = item
+		}
+
+		item[method] = operationFor(route.Method, path, albumSchema)
+	}
+
+	return doc
+}
+
+// openAPIPath rewrites a gin route path's ":name" parameters into
+// OpenAPI's "{name}" form.
+func openAPIPath(ginPath string) string {
+	return paramPattern.ReplaceAllString(ginPath, "{$1}")
+}
+
+// operationFor builds the Operation for a single route. Request bodies
+// and parameters are inferred from the method and whether the path
+// references the album resource.
+func operationFor(method, path string, albumSchema *Schema) Operation {
+	op := Operation{
+		Summary:   method + " " + path,
+		Responses: map[string]Response{"200": {Description: "OK"}},
+	}
+
+	for _, name := range pathParamNames(path) {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	if strings.HasPrefix(path, "/albums") && (method == "POST" || method == "PUT" || method == "PATCH") {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]MediaType{
+				"application/json": {Schema: albumSchema},
+			},
+		}
+	}
+
+	if strings.HasPrefix(path, "/albums") && method == "GET" {
+		schema := albumSchema
+		if path == "/albums" {
+			schema = albumsResponseSchema(albumSchema)
+		}
+		op.Responses["200"] = Response{
+			Description: "OK",
+			Content: map[string]MediaType{
+				"application/json": {Schema: schema},
+			},
+		}
+	}
+
+	return op
+}
+
+// albumsResponseSchema describes the {data, next_cursor, total} envelope
+// returned by GET /albums, wrapping albumSchema as the element type of
+// its data array.
+func albumsResponseSchema(albumSchema *Schema) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"data":        {Type: "array", Items: albumSchema},
+			"next_cursor": {Type: "string"},
+			"total":       {Type: "integer"},
+		},
+	}
+}
+
+// pathParamNames returns the "{name}" placeholders found in an OpenAPI
+// path, in order.
+func pathParamNames(path string) []string {
+	var names []string
+	for _, part := range strings.Split(path, "/") {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			names = append(names, strings.TrimSuffix(strings.TrimPrefix(part, "{"), "}"))
+		}
+	}
+	return names
+}
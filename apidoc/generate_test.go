@@ -0,0 +1,35 @@
+package apidoc
+
+import "testing"
+
+func TestOperationForAlbumsCollectionUsesEnvelopeSchema(t *testing.T) {
+	albumSchema := &Schema{Type: "object"}
+
+	op := operationFor("GET", "/albums", albumSchema)
+
+	schema := op.Responses["200"].Content["application/json"].Schema
+	if schema.Type != "object" {
+		t.Fatalf("schema.Type = %q, want %q", schema.Type, "object")
+	}
+	data, ok := schema.Properties["data"]
+	if !ok || data.Type != "array" || data.Items != albumSchema {
+		t.Errorf("Properties[\"data\"] = %+v, want an array of albumSchema", data)
+	}
+	if _, ok := schema.Properties["next_cursor"]; !ok {
+		t.Error("Properties missing \"next_cursor\"")
+	}
+	if _, ok := schema.Properties["total"]; !ok {
+		t.Error("Properties missing \"total\"")
+	}
+}
+
+func TestOperationForSingleAlbumUsesAlbumSchema(t *testing.T) {
+	albumSchema := &Schema{Type: "object"}
+
+	op := operationFor("GET", "/albums/{id}", albumSchema)
+
+	schema := op.Responses["200"].Content["application/json"].Schema
+	if schema != albumSchema {
+		t.Errorf("schema = %+v, want albumSchema unchanged", schema)
+	}
+}
@@ -0,0 +1,85 @@
+package apidoc
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SchemaFromStruct builds a Schema describing the exported fields of the
+// given struct type, using its json tag for the property name and its
+// example/description tags for documentation.
+func SchemaFromStruct(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	props := make(map[string]*Schema, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := jsonName(f)
+		if !ok {
+			continue
+		}
+		props[name] = fieldSchema(f)
+	}
+
+	return &Schema{
+		Type:       "object",
+		Properties: props,
+	}
+}
+
+// jsonName returns the JSON property name for f, or ok=false if the field
+// is unexported or tagged json:"-".
+func jsonName(f reflect.StructField) (string, bool) {
+	if f.PkgPath != "" {
+		return "", false
+	}
+	tag := f.Tag.Get("json")
+	name := strings.Split(tag, ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = f.Name
+	}
+	return name, true
+}
+
+// fieldSchema builds the Schema for a single struct field from its Go
+// type plus its example/description struct tags.
+func fieldSchema(f reflect.StructField) *Schema {
+	s := &Schema{
+		Description: f.Tag.Get("description"),
+	}
+
+	switch f.Type.Kind() {
+	case reflect.String:
+		s.Type = "string"
+		if ex := f.Tag.Get("example"); ex != "" {
+			s.Example = ex
+		}
+	case reflect.Float32, reflect.Float64:
+		s.Type = "number"
+		s.Format = "float"
+		if ex := f.Tag.Get("example"); ex != "" {
+			if v, err := strconv.ParseFloat(ex, 64); err == nil {
+				s.Example = v
+			}
+		}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		s.Type = "integer"
+		if ex := f.Tag.Get("example"); ex != "" {
+			if v, err := strconv.ParseInt(ex, 10, 64); err == nil {
+				s.Example = v
+			}
+		}
+	case reflect.Bool:
+		s.Type = "boolean"
+	default:
+		s.Type = "object"
+	}
+
+	return s
+}
@@ -0,0 +1,75 @@
+package apidoc
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testAlbum struct {
+	ID         string  `json:"id"`
+	Title      string  `json:"title" example:"Blue Train"`
+	Price      float32 `json:"price" example:"56.99" description:"price in USD"`
+	Year       int     `json:"year" example:"1957"`
+	InStock    bool    `json:"in_stock"`
+	Secret     string  `json:"-"`
+	unexported string
+}
+
+func TestSchemaFromStruct(t *testing.T) {
+	s := SchemaFromStruct(reflect.TypeOf(testAlbum{}))
+
+	if s.Type != "object" {
+		t.Fatalf("Type = %q, want %q", s.Type, "object")
+	}
+
+	for _, name := range []string{"Secret", "unexported"} {
+		if _, ok := s.Properties[name]; ok {
+			t.Errorf("Properties contains %q, want it excluded", name)
+		}
+	}
+
+	title, ok := s.Properties["title"]
+	if !ok {
+		t.Fatalf("Properties missing %q", "title")
+	}
+	if title.Type != "string" || title.Example != "Blue Train" {
+		t.Errorf("title schema = %+v, want type string, example %q", title, "Blue Train")
+	}
+
+	price, ok := s.Properties["price"]
+	if !ok {
+		t.Fatalf("Properties missing %q", "price")
+	}
+	if price.Type != "number" || price.Format != "float" || price.Description != "price in USD" {
+		t.Errorf("price schema = %+v, want type number, format float, description set", price)
+	}
+	if price.Example != 56.99 {
+		t.Errorf("price.Example = %v, want 56.99", price.Example)
+	}
+
+	year, ok := s.Properties["year"]
+	if !ok {
+		t.Fatalf("Properties missing %q", "year")
+	}
+	if year.Type != "integer" || year.Example != int64(1957) {
+		t.Errorf("year schema = %+v, want type integer, example 1957", year)
+	}
+
+	inStock, ok := s.Properties["in_stock"]
+	if !ok {
+		t.Fatalf("Properties missing %q", "in_stock")
+	}
+	if inStock.Type != "boolean" {
+		t.Errorf("in_stock.Type = %q, want %q", inStock.Type, "boolean")
+	}
+}
+
+func TestSchemaFromStructPointer(t *testing.T) {
+	s := SchemaFromStruct(reflect.TypeOf(&testAlbum{}))
+	if s.Type != "object" {
+		t.Fatalf("Type = %q, want %q", s.Type, "object")
+	}
+	if _, ok := s.Properties["id"]; !ok {
+		t.Errorf("Properties missing %q", "id")
+	}
+}
@@ -0,0 +1,28 @@
+package apidoc
+
+import "fmt"
+
+// SwaggerUIHTML returns a Swagger UI page (loaded from a CDN) configured
+// to fetch its spec from specURL.
+func SwaggerUIHTML(specURL string) []byte {
+	return []byte(fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+  <title>API docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      SwaggerUIBundle({
+        url: %q,
+        dom_id: "#swagger-ui",
+      });
+    };
+  </script>
+</body>
+</html>
+`, specURL))
+}
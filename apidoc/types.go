@@ -0,0 +1,66 @@
+// Package apidoc generates an OpenAPI 3.0 document describing a gin
+// router's registered routes, plus an embedded Swagger UI page that
+// renders it.
+package apidoc
+
+// Document is a minimal OpenAPI 3.0 document — only the fields this
+// package populates.
+type Document struct {
+	OpenAPI string              `json:"openapi"`
+	Info    Info                `json:"info"`
+	Paths   map[string]PathItem `json:"paths"`
+}
+
+// Info is the OpenAPI "info" object.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem groups the operations available on a single path, keyed by
+// lowercase HTTP method.
+type PathItem map[string]Operation
+
+// Operation describes a single route.
+type Operation struct {
+	Summary     string              `json:"summary"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter describes a path or query parameter.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody describes a JSON request body.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a single response.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType pairs a content type with the schema of its body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a minimal JSON Schema, enough to describe the album type.
+type Schema struct {
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Example     interface{}        `json:"example,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"example/web-service-gin/errs"
+)
+
+// APIKeyHeader is the header clients present their API key in.
+const APIKeyHeader = "X-API-Key"
+
+// LoadAPIKeys reads API keys from path, one "key:user" pair per line.
+// Blank lines and lines starting with "#" are ignored.
+func LoadAPIKeys(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open api key file: %w", err)
+	}
+	defer f.Close()
+
+	keys := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, user, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid api key line %q: expected key:user", line)
+		}
+		keys[key] = user
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read api key file: %w", err)
+	}
+	return keys, nil
+}
+
+// ParseAPIKeysEnv parses the comma-separated "key:user,key:user" format
+// used by the ALBUM_API_KEYS environment variable.
+func ParseAPIKeysEnv(env string) (map[string]string, error) {
+	keys := map[string]string{}
+	if env == "" {
+		return keys, nil
+	}
+	for _, pair := range strings.Split(env, ",") {
+		key, user, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid api key entry %q: expected key:user", pair)
+		}
+		keys[key] = user
+	}
+	return keys, nil
+}
+
+// APIKeyMiddleware returns gin middleware that authenticates requests
+// bearing a valid key in the X-API-Key header against keys.
+func APIKeyMiddleware(keys map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			errs.Write(c, fmt.Errorf("missing API key: %w", errs.ErrUnauthorized))
+			c.Abort()
+			return
+		}
+
+		user, ok := keys[key]
+		if !ok {
+			errs.Write(c, fmt.Errorf("invalid API key: %w", errs.ErrUnauthorized))
+			c.Abort()
+			return
+		}
+
+		setUser(c, User{ID: user, Source: "api_key"})
+		c.Next()
+	}
+}
@@ -0,0 +1,34 @@
+// Package auth provides gin middleware for authenticating requests to the
+// album API, via static API keys or JWT bearer tokens.
+package auth
+
+import "github.com/gin-gonic/gin"
+
+// userContextKey is the gin context key under which the authenticated
+// user is stored.
+const userContextKey = "user"
+
+// User identifies the caller that a request was authenticated as.
+type User struct {
+	// ID is the API key's or JWT subject claim's identifier.
+	ID string
+	// Source identifies which middleware authenticated the request,
+	// e.g. "api_key" or "jwt".
+	Source string
+}
+
+// CurrentUser returns the User attached to c by an auth middleware, or
+// ok=false if the request was not authenticated.
+func CurrentUser(c *gin.Context) (User, bool) {
+	v, ok := c.Get(userContextKey)
+	if !ok {
+		return User{}, false
+	}
+	u, ok := v.(User)
+	return u, ok
+}
+
+// setUser attaches u to c's context under userContextKey.
+func setUser(c *gin.Context, u User) {
+	c.Set(userContextKey, u)
+}
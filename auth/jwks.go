@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// jwksRefreshInterval is how long a fetched JWKS document is trusted
+// before jwksCache re-fetches it, so rotated keys are picked up.
+const jwksRefreshInterval = 10 * time.Minute
+
+// jwksFetchTimeout bounds how long a single JWKS fetch may take, so a
+// slow or hung identity provider can't stall requests indefinitely.
+const jwksFetchTimeout = 5 * time.Second
+
+// jwksCache fetches and caches RSA public keys from a JWKS endpoint,
+// re-fetching periodically so key rotation on the identity provider's
+// side is picked up automatically.
+type jwksCache struct {
+	url    string
+	client *http.Client
+
+	mu      sync.Mutex
+	keys    map[string]*rsa.PublicKey
+	fetched time.Time
+
+	// fetchMu serializes refetches so a burst of requests hitting a
+	// stale cache triggers one JWKS fetch, not one per request.
+	fetchMu sync.Mutex
+}
+
+func newJWKSCache(url string) *jwksCache {
+	return &jwksCache{
+		url:    url,
+		client: &http.Client{Timeout: jwksFetchTimeout},
+	}
+}
+
+// key returns the RSA public key for the given JWK "kid", fetching (or
+// re-fetching, if the cache is stale) the JWKS document as needed. The
+// fetch itself runs without holding mu, so it can't block unrelated
+// cache reads; only one refetch runs at a time.
+func (c *jwksCache) key(kid string) (*rsa.PublicKey, error) {
+	if key, ok := c.cachedKey(kid); ok {
+		return key, nil
+	}
+
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+
+	// Another goroutine may have refreshed the cache while we waited
+	// for fetchMu; check again before fetching.
+	if key, ok := c.cachedKey(kid); ok {
+		return key, nil
+	}
+
+	keys, err := fetchJWKS(c.client, c.url)
+	if err != nil {
+		c.mu.Lock()
+		key, ok := c.keys[kid]
+		c.mu.Unlock()
+		if ok {
+			// Serve the stale key rather than fail outright if the
+			// provider is temporarily unreachable.
+			return key, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.fetched = time.Now()
+	c.mu.Unlock()
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("no JWKS key found for kid %q", kid)
+	}
+	return key, nil
+}
+
+// cachedKey returns the key for kid if the cache holds it and isn't
+// stale.
+func (c *jwksCache) cachedKey(kid string) (*rsa.PublicKey, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key, ok := c.keys[kid]
+	if !ok || time.Since(c.fetched) >= jwksRefreshInterval {
+		return nil, false
+	}
+	return key, true
+}
+
+// jwk is a single entry in a JWKS document's "keys" array.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// fetchJWKS downloads and parses the JWKS document at url into a map of
+// kid to RSA public key.
+func fetchJWKS(client *http.Client, url string) (map[string]*rsa.PublicKey, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		pub, err := k.rsaPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("parse JWKS key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = pub
+	}
+	return keys, nil
+}
+
+// rsaPublicKey decodes a JWK's base64url-encoded modulus and exponent
+// into an *rsa.PublicKey.
+func (k jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("decode modulus: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("decode exponent: %w", err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
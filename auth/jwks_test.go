@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// jwksServer starts an httptest server serving a JWKS document with a
+// single RSA key under kid, and returns the server and the private key
+// so callers can verify it matches the parsed public key.
+func jwksServer(t *testing.T, kid string) (*httptest.Server, *rsa.PrivateKey) {
+	t.Helper()
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	n := base64.RawURLEncoding.EncodeToString(priv.PublicKey.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString([]byte{1, 0, 1}) // 65537
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{
+				{"kid": kid, "kty": "RSA", "n": n, "e": e},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, priv
+}
+
+func TestJWKSCacheKeyFetchesAndCaches(t *testing.T) {
+	var fetches int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{
+				{"kid": "k1", "kty": "RSA", "n": base64.RawURLEncoding.EncodeToString([]byte{1, 2, 3}), "e": "AQAB"},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	c := newJWKSCache(srv.URL)
+
+	if _, err := c.key("k1"); err != nil {
+		t.Fatalf("key() error = %v", err)
+	}
+	if _, err := c.key("k1"); err != nil {
+		t.Fatalf("key() error = %v", err)
+	}
+	if fetches != 1 {
+		t.Errorf("fetches = %d, want 1 (second call should hit the cache)", fetches)
+	}
+}
+
+func TestJWKSCacheKeyMatchesPublicKey(t *testing.T) {
+	srv, priv := jwksServer(t, "k1")
+	defer srv.Close()
+
+	c := newJWKSCache(srv.URL)
+	got, err := c.key("k1")
+	if err != nil {
+		t.Fatalf("key() error = %v", err)
+	}
+	if got.N.Cmp(priv.PublicKey.N) != 0 || got.E != priv.PublicKey.E {
+		t.Error("key() did not return the published public key")
+	}
+}
+
+func TestJWKSCacheKeyServesStaleOnFetchError(t *testing.T) {
+	srv, _ := jwksServer(t, "k1")
+
+	c := newJWKSCache(srv.URL)
+	if _, err := c.key("k1"); err != nil {
+		t.Fatalf("key() error = %v", err)
+	}
+
+	srv.Close() // subsequent fetches now fail
+	c.fetched = time.Now().Add(-2 * jwksRefreshInterval)
+
+	if _, err := c.key("k1"); err != nil {
+		t.Errorf("key() error = %v, want the stale key served instead", err)
+	}
+}
+
+func TestFetchJWKSRespectsClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Timeout: 10 * time.Millisecond}
+	if _, err := fetchJWKS(client, srv.URL); err == nil {
+		t.Error("fetchJWKS() error = nil, want a timeout error from the bounded client")
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"example/web-service-gin/auth"
+)
+
+// newAuthMiddleware builds the gin middleware that protects the mutating
+// album routes, selected by mode ("", "none", "api_key", or "jwt") and
+// falling back to the ALBUM_AUTH environment variable. It returns a nil
+// middleware if auth is disabled.
+func newAuthMiddleware(mode string) (gin.HandlerFunc, error) {
+	if mode == "" {
+		mode = os.Getenv("ALBUM_AUTH")
+	}
+
+	switch mode {
+	case "", "none":
+		return nil, nil
+	case "api_key":
+		return newAPIKeyMiddleware()
+	case "jwt":
+		return newJWTMiddleware()
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q", mode)
+	}
+}
+
+// newAPIKeyMiddleware loads API keys from ALBUM_API_KEYS_FILE (a
+// "key:user" per line file) or ALBUM_API_KEYS (a "key:user,key:user"
+// value) and returns the resulting middleware.
+func newAPIKeyMiddleware() (gin.HandlerFunc, error) {
+	if path := os.Getenv("ALBUM_API_KEYS_FILE"); path != "" {
+		keys, err := auth.LoadAPIKeys(path)
+		if err != nil {
+			return nil, err
+		}
+		return auth.APIKeyMiddleware(keys), nil
+	}
+
+	keys, err := auth.ParseAPIKeysEnv(os.Getenv("ALBUM_API_KEYS"))
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("api_key auth requires ALBUM_API_KEYS_FILE or ALBUM_API_KEYS")
+	}
+	return auth.APIKeyMiddleware(keys), nil
+}
+
+// newJWTMiddleware builds a JWT middleware from ALBUM_JWT_* environment
+// variables, enabling HS256 verification when a static secret is
+// configured and RS256 verification when a JWKS URL is (both, if both are).
+func newJWTMiddleware() (gin.HandlerFunc, error) {
+	cfg := auth.JWTConfig{
+		Issuer:   os.Getenv("ALBUM_JWT_ISSUER"),
+		Audience: os.Getenv("ALBUM_JWT_AUDIENCE"),
+		JWKSURL:  os.Getenv("ALBUM_JWT_JWKS_URL"),
+	}
+
+	if secret := os.Getenv("ALBUM_JWT_HS256_SECRET"); secret != "" {
+		cfg.HS256Secret = []byte(secret)
+	}
+
+	if cfg.HS256Secret == nil && cfg.JWKSURL == "" {
+		return nil, fmt.Errorf("jwt auth requires ALBUM_JWT_HS256_SECRET or ALBUM_JWT_JWKS_URL")
+	}
+
+	return auth.JWTMiddleware(cfg), nil
+}
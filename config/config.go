@@ -0,0 +1,199 @@
+// Package config loads server configuration from flags, environment
+// variables, and an optional YAML file, in that order of precedence
+// (flags win, then env vars, then the file, then built-in defaults).
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the settings needed to start the server.
+type Config struct {
+	ListenAddr      string        `yaml:"listen_addr"`
+	TLSCertFile     string        `yaml:"tls_cert_file"`
+	TLSKeyFile      string        `yaml:"tls_key_file"`
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	StorageBackend  string        `yaml:"storage_backend"`
+	StorageDSN      string        `yaml:"storage_dsn"`
+}
+
+// defaults returns the configuration used when no flag, env var, or YAML
+// file sets a value.
+func defaults() Config {
+	return Config{
+		ListenAddr:      ":8080",
+		ReadTimeout:     15 * time.Second,
+		WriteTimeout:    15 * time.Second,
+		ShutdownTimeout: 10 * time.Second,
+		StorageBackend:  "memory",
+	}
+}
+
+// TLSEnabled reports whether both a TLS certificate and key were
+// configured.
+func (c Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// Load builds a Config from fs's registered flags (parsed from args),
+// falling back to ALBUM_* environment variables and then to the YAML
+// file named by --config or ALBUM_CONFIG_FILE, in that precedence order.
+func Load(fs *flag.FlagSet, args []string) (Config, error) {
+	cfg := defaults()
+
+	var flags struct {
+		config          string
+		listenAddr      string
+		tlsCert         string
+		tlsKey          string
+		readTimeout     string
+		writeTimeout    string
+		shutdownTimeout string
+		storageBackend  string
+		storageDSN      string
+	}
+
+	fs.StringVar(&flags.config, "config", "", "path to a YAML config file")
+	fs.StringVar(&flags.listenAddr, "listen-addr", "", "address to listen on (default :8080)")
+	fs.StringVar(&flags.tlsCert, "tls-cert", "", "path to a TLS certificate file; enables HTTPS/HTTP2 with -tls-key")
+	fs.StringVar(&flags.tlsKey, "tls-key", "", "path to a TLS private key file; enables HTTPS/HTTP2 with -tls-cert")
+	fs.StringVar(&flags.readTimeout, "read-timeout", "", "HTTP read timeout (default 15s)")
+	fs.StringVar(&flags.writeTimeout, "write-timeout", "", "HTTP write timeout (default 15s)")
+	fs.StringVar(&flags.shutdownTimeout, "shutdown-timeout", "", "graceful shutdown drain timeout (default 10s)")
+	fs.StringVar(&flags.storageBackend, "storage", "", "storage backend: memory, sqlite, or postgres (default memory)")
+	fs.StringVar(&flags.storageDSN, "dsn", "", "data source name for the sqlite/postgres backend")
+
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	if path := firstNonEmpty(flags.config, os.Getenv("ALBUM_CONFIG_FILE")); path != "" {
+		if err := mergeYAMLFile(&cfg, path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := mergeEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+
+	if err := mergeFlags(&cfg, flags.listenAddr, flags.tlsCert, flags.tlsKey,
+		flags.readTimeout, flags.writeTimeout, flags.shutdownTimeout,
+		flags.storageBackend, flags.storageDSN); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// mergeYAMLFile overlays the settings in the YAML file at path onto cfg.
+func mergeYAMLFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// mergeEnv overlays ALBUM_* environment variables onto cfg.
+func mergeEnv(cfg *Config) error {
+	if v := os.Getenv("ALBUM_LISTEN_ADDR"); v != "" {
+		cfg.ListenAddr = v
+	}
+	if v := os.Getenv("ALBUM_TLS_CERT_FILE"); v != "" {
+		cfg.TLSCertFile = v
+	}
+	if v := os.Getenv("ALBUM_TLS_KEY_FILE"); v != "" {
+		cfg.TLSKeyFile = v
+	}
+	if v := os.Getenv("ALBUM_READ_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("parse ALBUM_READ_TIMEOUT: %w", err)
+		}
+		cfg.ReadTimeout = d
+	}
+	if v := os.Getenv("ALBUM_WRITE_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("parse ALBUM_WRITE_TIMEOUT: %w", err)
+		}
+		cfg.WriteTimeout = d
+	}
+	if v := os.Getenv("ALBUM_SHUTDOWN_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("parse ALBUM_SHUTDOWN_TIMEOUT: %w", err)
+		}
+		cfg.ShutdownTimeout = d
+	}
+	if v := os.Getenv("ALBUM_STORE"); v != "" {
+		cfg.StorageBackend = v
+	}
+	if v := os.Getenv("ALBUM_STORE_DSN"); v != "" {
+		cfg.StorageDSN = v
+	}
+	return nil
+}
+
+// mergeFlags overlays flags explicitly passed on the command line onto
+// cfg; empty strings mean "not set" and are left alone.
+func mergeFlags(cfg *Config, listenAddr, tlsCert, tlsKey, readTimeout, writeTimeout, shutdownTimeout, storageBackend, storageDSN string) error {
+	if listenAddr != "" {
+		cfg.ListenAddr = listenAddr
+	}
+	if tlsCert != "" {
+		cfg.TLSCertFile = tlsCert
+	}
+	if tlsKey != "" {
+		cfg.TLSKeyFile = tlsKey
+	}
+	if readTimeout != "" {
+		d, err := time.ParseDuration(readTimeout)
+		if err != nil {
+			return fmt.Errorf("parse -read-timeout: %w", err)
+		}
+		cfg.ReadTimeout = d
+	}
+	if writeTimeout != "" {
+		d, err := time.ParseDuration(writeTimeout)
+		if err != nil {
+			return fmt.Errorf("parse -write-timeout: %w", err)
+		}
+		cfg.WriteTimeout = d
+	}
+	if shutdownTimeout != "" {
+		d, err := time.ParseDuration(shutdownTimeout)
+		if err != nil {
+			return fmt.Errorf("parse -shutdown-timeout: %w", err)
+		}
+		cfg.ShutdownTimeout = d
+	}
+	if storageBackend != "" {
+		cfg.StorageBackend = storageBackend
+	}
+	if storageDSN != "" {
+		cfg.StorageDSN = storageDSN
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first non-empty string in vs.
+func firstNonEmpty(vs ...string) string {
+	for _, v := range vs {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg != defaults() {
+		t.Errorf("Load() = %+v, want defaults %+v", cfg, defaults())
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("listen_addr: :9000\nstorage_backend: sqlite\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("ALBUM_CONFIG_FILE", path)
+	t.Setenv("ALBUM_LISTEN_ADDR", ":9001")
+	t.Setenv("ALBUM_STORE", "")
+
+	// Env var (:9001) should win over the file (:9000); the file's
+	// storage_backend should survive since no env var or flag overrides it.
+	cfg, err := Load(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.ListenAddr != ":9001" {
+		t.Errorf("ListenAddr = %q, want %q", cfg.ListenAddr, ":9001")
+	}
+	if cfg.StorageBackend != "sqlite" {
+		t.Errorf("StorageBackend = %q, want %q", cfg.StorageBackend, "sqlite")
+	}
+
+	// A flag should win over both the env var and the file.
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg, err = Load(fs, []string{"-listen-addr=:9002"})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.ListenAddr != ":9002" {
+		t.Errorf("ListenAddr = %q, want %q", cfg.ListenAddr, ":9002")
+	}
+}
+
+func TestLoadInvalidDuration(t *testing.T) {
+	t.Setenv("ALBUM_READ_TIMEOUT", "not-a-duration")
+	if _, err := Load(flag.NewFlagSet("test", flag.ContinueOnError), nil); err == nil {
+		t.Fatal("Load() error = nil, want error for invalid ALBUM_READ_TIMEOUT")
+	}
+}
+
+func TestTLSEnabled(t *testing.T) {
+	cfg := Config{}
+	if cfg.TLSEnabled() {
+		t.Error("TLSEnabled() = true, want false with no cert/key set")
+	}
+
+	cfg.TLSCertFile = "cert.pem"
+	if cfg.TLSEnabled() {
+		t.Error("TLSEnabled() = true, want false with only a cert set")
+	}
+
+	cfg.TLSKeyFile = "key.pem"
+	if !cfg.TLSEnabled() {
+		t.Error("TLSEnabled() = false, want true with both cert and key set")
+	}
+}
+
+func TestFirstNonEmpty(t *testing.T) {
+	if got := firstNonEmpty("", "", "c", "d"); got != "c" {
+		t.Errorf("firstNonEmpty() = %q, want %q", got, "c")
+	}
+	if got := firstNonEmpty("", ""); got != "" {
+		t.Errorf("firstNonEmpty() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,46 @@
+// Package errs defines the sentinel errors used across the album API and
+// the machinery for reporting them to clients as RFC 7807 problem+json
+// responses.
+package errs
+
+import "errors"
+
+// Sentinel errors returned by the store and handler layers. Wrap these
+// with fmt.Errorf("...: %w", ErrX) to add context while staying
+// errors.Is-compatible.
+var (
+	// ErrNotFound indicates the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrValidation indicates the request failed validation.
+	ErrValidation = errors.New("validation failed")
+
+	// ErrConflict indicates the request conflicts with existing state.
+	ErrConflict = errors.New("conflict")
+
+	// ErrUnauthorized indicates the request lacks valid authentication.
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// FieldError describes a single invalid field, for the problem+json
+// response's "errors" array.
+type FieldError struct {
+	Field  string `json:"field"`
+	Detail string `json:"detail"`
+}
+
+// ValidationError is an ErrValidation carrying the specific fields that
+// failed validation.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return ErrValidation.Error()
+}
+
+// Unwrap allows errors.Is(err, ErrValidation) to succeed for a
+// *ValidationError.
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
@@ -0,0 +1,54 @@
+package errs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"example/web-service-gin/obs"
+)
+
+// Write sends err to c as an application/problem+json response, using
+// c.Request.URL.Path as the problem's "instance". Internal (500) errors
+// are logged server-side, since their detail is withheld from the
+// client response.
+func Write(c *gin.Context, err error) {
+	problem := ToProblem(err, c.Request.URL.Path)
+	if problem.Status == http.StatusInternalServerError {
+		obs.Logger.Error("internal_error",
+			"request_id", obs.RequestID(c),
+			"path", c.Request.URL.Path,
+			"error", err.Error(),
+		)
+	}
+	c.Data(problem.Status, ProblemContentType, mustJSON(problem))
+}
+
+// Recovery returns gin middleware that recovers panics in later
+// handlers and reports them as a 500 problem+json response instead of
+// crashing the server or falling through to gin's default recovery.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				Write(c, fmt.Errorf("panic: %v", r))
+				c.Abort()
+			}
+		}()
+		c.Next()
+	}
+}
+
+// mustJSON marshals p, falling back to a minimal hand-written problem
+// body in the unexpected case that marshaling itself fails.
+func mustJSON(p Problem) []byte {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return []byte(fmt.Sprintf(
+			`{"type":"about:blank","title":"Internal Server Error","status":%d}`,
+			http.StatusInternalServerError))
+	}
+	return b
+}
@@ -0,0 +1,77 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ProblemContentType is the media type used for RFC 7807 responses.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 "problem details" object.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// ToProblem maps err to the Problem that should be reported for it,
+// classifying by the sentinel error it wraps. Unrecognized errors map to
+// a generic 500.
+func ToProblem(err error, instance string) Problem {
+	var ve *ValidationError
+
+	switch {
+	case errors.As(err, &ve):
+		return Problem{
+			Type:     "about:blank",
+			Title:    "Validation Failed",
+			Status:   http.StatusBadRequest,
+			Detail:   err.Error(),
+			Instance: instance,
+			Errors:   ve.Fields,
+		}
+	case errors.Is(err, ErrValidation):
+		return Problem{
+			Type:     "about:blank",
+			Title:    "Validation Failed",
+			Status:   http.StatusBadRequest,
+			Detail:   err.Error(),
+			Instance: instance,
+		}
+	case errors.Is(err, ErrNotFound):
+		return Problem{
+			Type:     "about:blank",
+			Title:    "Not Found",
+			Status:   http.StatusNotFound,
+			Detail:   err.Error(),
+			Instance: instance,
+		}
+	case errors.Is(err, ErrConflict):
+		return Problem{
+			Type:     "about:blank",
+			Title:    "Conflict",
+			Status:   http.StatusConflict,
+			Detail:   err.Error(),
+			Instance: instance,
+		}
+	case errors.Is(err, ErrUnauthorized):
+		return Problem{
+			Type:     "about:blank",
+			Title:    "Unauthorized",
+			Status:   http.StatusUnauthorized,
+			Detail:   err.Error(),
+			Instance: instance,
+		}
+	default:
+		return Problem{
+			Type:     "about:blank",
+			Title:    "Internal Server Error",
+			Status:   http.StatusInternalServerError,
+			Instance: instance,
+		}
+	}
+}
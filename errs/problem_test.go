@@ -0,0 +1,62 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestToProblemMapsSentinelErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantTitle  string
+	}{
+		{"not found", fmt.Errorf("album %q: %w", "1", ErrNotFound), http.StatusNotFound, "Not Found"},
+		{"conflict", fmt.Errorf("album %q: %w", "1", ErrConflict), http.StatusConflict, "Conflict"},
+		{"unauthorized", fmt.Errorf("missing token: %w", ErrUnauthorized), http.StatusUnauthorized, "Unauthorized"},
+		{"validation", ErrValidation, http.StatusBadRequest, "Validation Failed"},
+		{"unknown", errors.New("boom"), http.StatusInternalServerError, "Internal Server Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ToProblem(tt.err, "/albums/1")
+			if p.Status != tt.wantStatus {
+				t.Errorf("Status = %d, want %d", p.Status, tt.wantStatus)
+			}
+			if p.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", p.Title, tt.wantTitle)
+			}
+			if p.Instance != "/albums/1" {
+				t.Errorf("Instance = %q, want %q", p.Instance, "/albums/1")
+			}
+		})
+	}
+}
+
+func TestToProblemHidesInternalErrorDetail(t *testing.T) {
+	p := ToProblem(fmt.Errorf("create album: UNIQUE constraint failed: albums.id"), "/albums")
+
+	if p.Status != http.StatusInternalServerError {
+		t.Errorf("Status = %d, want %d", p.Status, http.StatusInternalServerError)
+	}
+	if p.Detail != "" {
+		t.Errorf("Detail = %q, want empty so internal errors aren't leaked to clients", p.Detail)
+	}
+}
+
+func TestToProblemValidationErrorCarriesFields(t *testing.T) {
+	ve := &ValidationError{Fields: []FieldError{{Field: "price", Detail: "must be positive"}}}
+
+	p := ToProblem(ve, "/albums")
+
+	if p.Status != http.StatusBadRequest {
+		t.Errorf("Status = %d, want %d", p.Status, http.StatusBadRequest)
+	}
+	if len(p.Errors) != 1 || p.Errors[0] != ve.Fields[0] {
+		t.Errorf("Errors = %+v, want %+v", p.Errors, ve.Fields)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/net/http2"
+
+	"example/web-service-gin/config"
+	"example/web-service-gin/store"
+)
+
+// runServer starts an *http.Server for handler configured from cfg, then
+// blocks until SIGINT or SIGTERM, at which point it drains in-flight
+// requests (bounded by cfg.ShutdownTimeout) and closes store before
+// returning.
+//
+// It serves HTTPS with HTTP/2 when cfg has a TLS certificate and key
+// configured, and plain HTTP otherwise.
+func runServer(cfg config.Config, handler http.Handler, s store.AlbumStore) error {
+	srv := &http.Server{
+		Addr:         cfg.ListenAddr,
+		Handler:      handler,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+	}
+
+	if cfg.TLSEnabled() {
+		if err := http2.ConfigureServer(srv, nil); err != nil {
+			return err
+		}
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		var err error
+		if cfg.TLSEnabled() {
+			log.Printf("listening on %s (TLS/HTTP2)", cfg.ListenAddr)
+			err = srv.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+		} else {
+			log.Printf("listening on %s", cfg.ListenAddr)
+			err = srv.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+			return
+		}
+		serveErr <- nil
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-serveErr:
+		return err
+	case sig := <-quit:
+		log.Printf("received %s, shutting down", sig)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	shutdownErr := srv.Shutdown(ctx)
+	<-serveErr
+
+	if closer, ok := s.(io.Closer); ok {
+		if err := closer.Close(); err != nil && shutdownErr == nil {
+			shutdownErr = err
+		}
+	}
+
+	return shutdownErr
+}
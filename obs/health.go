@@ -0,0 +1,33 @@
+package obs
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Pinger is the subset of store.AlbumStore that Readyz needs to check
+// the storage backend is reachable.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Healthz is a liveness probe: if the process can handle a request at
+// all, it reports healthy.
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz returns a readiness probe that also checks the storage backend
+// is reachable, so load balancers stop routing traffic to an instance
+// whose database connection has dropped.
+func Readyz(store Pinger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := store.Ping(c.Request.Context()); err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	}
+}
@@ -0,0 +1,70 @@
+package obs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader is the header the request ID is echoed back on.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDContextKey is the gin context key the request ID is stored
+// under.
+const requestIDContextKey = "request_id"
+
+// Logger is the package-wide structured logger. It writes JSON lines to
+// stdout by default; replace it (e.g. in tests) by assigning a new
+// *slog.Logger.
+var Logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+// RequestID returns the ID assigned to the in-flight request by the
+// Logging middleware.
+func RequestID(c *gin.Context) string {
+	id, _ := c.Get(requestIDContextKey)
+	s, _ := id.(string)
+	return s
+}
+
+// Logging returns gin middleware that assigns each request a random ID
+// and logs a JSON line summarizing it — method, route, status, duration,
+// and request ID — once it completes. It replaces gin.Default()'s
+// built-in logger.
+func Logging() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := newRequestID()
+		c.Set(requestIDContextKey, id)
+		c.Writer.Header().Set(RequestIDHeader, id)
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		Logger.Info("http_request",
+			"request_id", id,
+			"method", c.Request.Method,
+			"route", route,
+			"status", c.Writer.Status(),
+			"duration_ms", time.Since(start).Milliseconds(),
+		)
+	}
+}
+
+// newRequestID returns a random 16-character hex request ID.
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand.Read only fails if the system CSPRNG is broken;
+		// fall back to a fixed marker rather than panicking mid-request.
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
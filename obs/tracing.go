@@ -0,0 +1,48 @@
+package obs
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// InitTracer installs a global OpenTelemetry TracerProvider for
+// serviceName, exporting spans to stderr. It returns a shutdown func that
+// callers should defer to flush and close the exporter.
+//
+// Spans are written to stderr, not stdout, so they don't interleave with
+// the JSON log lines Logging writes to stdout.
+func InitTracer(serviceName string) (shutdown func(context.Context) error, err error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	if err != nil {
+		return nil, fmt.Errorf("create trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(),
+		resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("build trace resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// Tracing returns gin middleware that starts a span for every request and
+// injects the trace context so it propagates through the store layer.
+func Tracing(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName)
+}
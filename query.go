@@ -0,0 +1,131 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"example/web-service-gin/store"
+)
+
+// maxLimit is the largest page size getAllAlbums will honor.
+const maxLimit = 100
+
+// defaultLimit is the page size used when the client does not specify one.
+const defaultLimit = 20
+
+// AlbumQuery describes the supported query parameters for listing albums.
+type AlbumQuery struct {
+	Limit    int     `form:"limit,default=20" binding:"omitempty,min=1,max=100"`
+	Offset   int     `form:"offset" binding:"omitempty,min=0"`
+	After    string  `form:"after"`
+	Artist   string  `form:"artist"`
+	MinPrice float32 `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice float32 `form:"max_price" binding:"omitempty,min=0"`
+	Sort     string  `form:"sort"`
+}
+
+// albumsResponse is the JSON envelope returned by getAllAlbums so clients
+// can page through large catalogs.
+type albumsResponse struct {
+	Data       []*store.Album `json:"data"`
+	NextCursor string         `json:"next_cursor"`
+	Total      int            `json:"total"`
+}
+
+// filterAlbums returns the albums matching the artist and price bounds in
+// q. Zero-valued bounds are treated as "no bound".
+func filterAlbums(albums []*store.Album, q AlbumQuery) []*store.Album {
+	out := albums[:0:0]
+	for _, a := range albums {
+		if q.Artist != "" && !strings.EqualFold(a.Artist, q.Artist) {
+			continue
+		}
+		if q.MinPrice > 0 && a.Price < q.MinPrice {
+			continue
+		}
+		if q.MaxPrice > 0 && a.Price > q.MaxPrice {
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// sortAlbums sorts albums in place according to a comma-separated list of
+// fields (title, artist, price), each optionally prefixed with "-" for
+// descending order. Unknown fields are ignored. With no spec, albums are
+// sorted by ID, so pagination is stable regardless of the store's
+// underlying iteration order.
+func sortAlbums(albums []*store.Album, spec string) {
+	if spec == "" {
+		sort.SliceStable(albums, func(i, j int) bool {
+			return albums[i].ID < albums[j].ID
+		})
+		return
+	}
+	fields := strings.Split(spec, ",")
+
+	sort.SliceStable(albums, func(i, j int) bool {
+		for _, f := range fields {
+			desc := strings.HasPrefix(f, "-")
+			f = strings.TrimPrefix(f, "-")
+
+			var less, greater bool
+			switch f {
+			case "title":
+				less, greater = albums[i].Title < albums[j].Title, albums[i].Title > albums[j].Title
+			case "artist":
+				less, greater = albums[i].Artist < albums[j].Artist, albums[i].Artist > albums[j].Artist
+			case "price":
+				less, greater = albums[i].Price < albums[j].Price, albums[i].Price > albums[j].Price
+			default:
+				continue
+			}
+			if less || greater {
+				if desc {
+					return greater
+				}
+				return less
+			}
+		}
+		return false
+	})
+}
+
+// paginateAlbums applies offset/limit to albums and returns the page along
+// with the cursor for the next page, if any remain. The after cursor, when
+// it is a valid position past offset, is used as the starting offset
+// instead; otherwise it is ignored.
+func paginateAlbums(albums []*store.Album, q AlbumQuery) ([]*store.Album, string) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	offset := q.Offset
+	if q.After != "" {
+		if n, err := strconv.Atoi(q.After); err == nil && n > offset {
+			offset = n
+		}
+	}
+
+	if offset >= len(albums) {
+		return []*store.Album{}, ""
+	}
+
+	end := offset + limit
+	if end > len(albums) {
+		end = len(albums)
+	}
+
+	var next string
+	if end < len(albums) {
+		next = strconv.Itoa(end)
+	}
+
+	return albums[offset:end], next
+}
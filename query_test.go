@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+
+	"example/web-service-gin/store"
+)
+
+func albumsByID(ids ...string) []*store.Album {
+	albums := make([]*store.Album, len(ids))
+	for i, id := range ids {
+		albums[i] = &store.Album{ID: id}
+	}
+	return albums
+}
+
+func idsOf(albums []*store.Album) []string {
+	ids := make([]string, len(albums))
+	for i, a := range albums {
+		ids[i] = a.ID
+	}
+	return ids
+}
+
+func TestFilterAlbums(t *testing.T) {
+	albums := []*store.Album{
+		{ID: "1", Artist: "John Coltrane", Price: 56.99},
+		{ID: "2", Artist: "Gerry Mulligan", Price: 17.99},
+		{ID: "3", Artist: "John Coltrane", Price: 39.99},
+	}
+
+	tests := []struct {
+		name string
+		q    AlbumQuery
+		want []string
+	}{
+		{name: "no filter", q: AlbumQuery{}, want: []string{"1", "2", "3"}},
+		{name: "artist", q: AlbumQuery{Artist: "john coltrane"}, want: []string{"1", "3"}},
+		{name: "min price", q: AlbumQuery{MinPrice: 40}, want: []string{"1"}},
+		{name: "max price", q: AlbumQuery{MaxPrice: 40}, want: []string{"2", "3"}},
+		{name: "min and max price", q: AlbumQuery{MinPrice: 20, MaxPrice: 40}, want: []string{"3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := idsOf(filterAlbums(albums, tt.q))
+			if !equalStrings(got, tt.want) {
+				t.Errorf("filterAlbums() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortAlbumsDefaultsToID(t *testing.T) {
+	albums := albumsByID("3", "1", "2")
+	sortAlbums(albums, "")
+	if got, want := idsOf(albums), []string{"1", "2", "3"}; !equalStrings(got, want) {
+		t.Errorf("sortAlbums(\"\") = %v, want %v", got, want)
+	}
+}
+
+func TestSortAlbumsBySpec(t *testing.T) {
+	albums := []*store.Album{
+		{ID: "1", Title: "Blue Train", Price: 56.99},
+		{ID: "2", Title: "Jeru", Price: 17.99},
+		{ID: "3", Title: "Sarah Vaughan", Price: 39.99},
+	}
+
+	sortAlbums(albums, "-price")
+	if got, want := idsOf(albums), []string{"1", "3", "2"}; !equalStrings(got, want) {
+		t.Errorf("sortAlbums(\"-price\") = %v, want %v", got, want)
+	}
+}
+
+func TestPaginateAlbums(t *testing.T) {
+	albums := albumsByID("1", "2", "3", "4", "5")
+
+	page, next := paginateAlbums(albums, AlbumQuery{Limit: 2})
+	if got, want := idsOf(page), []string{"1", "2"}; !equalStrings(got, want) {
+		t.Errorf("page 1 = %v, want %v", got, want)
+	}
+	if next != "2" {
+		t.Errorf("next cursor = %q, want %q", next, "2")
+	}
+
+	page, next = paginateAlbums(albums, AlbumQuery{Limit: 2, After: next})
+	if got, want := idsOf(page), []string{"3", "4"}; !equalStrings(got, want) {
+		t.Errorf("page 2 = %v, want %v", got, want)
+	}
+	if next != "4" {
+		t.Errorf("next cursor = %q, want %q", next, "4")
+	}
+
+	page, next = paginateAlbums(albums, AlbumQuery{Limit: 2, After: next})
+	if got, want := idsOf(page), []string{"5"}; !equalStrings(got, want) {
+		t.Errorf("page 3 = %v, want %v", got, want)
+	}
+	if next != "" {
+		t.Errorf("next cursor = %q, want empty", next)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore is an in-memory AlbumStore. It does not persist across
+// restarts and is intended for local development and tests.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	albums map[string]*Album
+}
+
+// NewMemoryStore returns a MemoryStore seeded with the given albums.
+func NewMemoryStore(seed []*Album) *MemoryStore {
+	albums := make(map[string]*Album, len(seed))
+	for _, a := range seed {
+		cp := *a
+		albums[a.ID] = &cp
+	}
+	return &MemoryStore{albums: albums}
+}
+
+// List returns every album in the store.
+func (s *MemoryStore) List(ctx context.Context) ([]*Album, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]*Album, 0, len(s.albums))
+	for _, a := range s.albums {
+		cp := *a
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+// Get returns the album with the given ID, or ErrNotFound if none exists.
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Album, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, ok := s.albums[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *a
+	return &cp, nil
+}
+
+// Create adds a new album to the store, or returns ErrConflict if an
+// album with the same ID already exists.
+func (s *MemoryStore) Create(ctx context.Context, a *Album) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.albums[a.ID]; ok {
+		return ErrConflict
+	}
+	cp := *a
+	s.albums[a.ID] = &cp
+	return nil
+}
+
+// Update replaces the album with the given ID, or returns ErrNotFound if
+// none exists.
+func (s *MemoryStore) Update(ctx context.Context, id string, a *Album) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.albums[id]
+	if !ok {
+		return ErrNotFound
+	}
+	cp := *a
+	cp.ID = id
+	cp.CreatedBy = existing.CreatedBy
+	s.albums[id] = &cp
+	return nil
+}
+
+// Delete removes the album with the given ID, or returns ErrNotFound if
+// none exists.
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.albums[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.albums, id)
+	return nil
+}
+
+// Ping always succeeds: a MemoryStore has no external backend to check.
+func (s *MemoryStore) Ping(ctx context.Context) error {
+	return nil
+}
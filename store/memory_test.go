@@ -0,0 +1,24 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryStoreCreateConflict(t *testing.T) {
+	s := NewMemoryStore([]*Album{{ID: "1", Title: "Blue Train"}})
+
+	err := s.Create(context.Background(), &Album{ID: "1", Title: "Duplicate"})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("Create() error = %v, want ErrConflict", err)
+	}
+
+	a, err := s.Get(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if a.Title != "Blue Train" {
+		t.Errorf("Title = %q, want original album to be unchanged", a.Title)
+	}
+}
@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// PostgresStore is an AlbumStore backed by a Postgres database.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore opens a connection to the Postgres database identified
+// by dsn and ensures the albums table exists.
+func NewPostgresStore(dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open postgres store: %w", err)
+	}
+	if err := db.PingContext(context.Background()); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connect to postgres: %w", err)
+	}
+
+	const schema = `
+CREATE TABLE IF NOT EXISTS albums (
+	id         TEXT PRIMARY KEY,
+	title      TEXT NOT NULL,
+	artist     TEXT NOT NULL,
+	price      REAL NOT NULL,
+	created_by TEXT NOT NULL DEFAULT ''
+)`
+	if _, err := db.ExecContext(context.Background(), schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create albums table: %w", err)
+	}
+
+	return &PostgresStore{db: db}, nil
+}
+
+// Close closes the underlying database connection.
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
+
+// Ping reports whether the database is reachable.
+func (s *PostgresStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// List returns every album in the store.
+func (s *PostgresStore) List(ctx context.Context) ([]*Album, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, title, artist, price, created_by FROM albums`)
+	if err != nil {
+		return nil, fmt.Errorf("list albums: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []*Album
+	for rows.Next() {
+		a := &Album{}
+		if err := rows.Scan(&a.ID, &a.Title, &a.Artist, &a.Price, &a.CreatedBy); err != nil {
+			return nil, fmt.Errorf("scan album: %w", err)
+		}
+		albums = append(albums, a)
+	}
+	return albums, rows.Err()
+}
+
+// Get returns the album with the given ID, or ErrNotFound if none exists.
+func (s *PostgresStore) Get(ctx context.Context, id string) (*Album, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT id, title, artist, price, created_by FROM albums WHERE id = $1`, id)
+
+	a := &Album{}
+	if err := row.Scan(&a.ID, &a.Title, &a.Artist, &a.Price, &a.CreatedBy); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get album: %w", err)
+	}
+	return a, nil
+}
+
+// pqUniqueViolation is the Postgres error code for a unique constraint
+// violation. See https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const pqUniqueViolation = "23505"
+
+// Create adds a new album to the store, or returns ErrConflict if an
+// album with the same ID already exists.
+func (s *PostgresStore) Create(ctx context.Context, a *Album) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO albums (id, title, artist, price, created_by) VALUES ($1, $2, $3, $4, $5)`,
+		a.ID, a.Title, a.Artist, a.Price, a.CreatedBy)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return ErrConflict
+		}
+		return fmt.Errorf("create album: %w", err)
+	}
+	return nil
+}
+
+// Update replaces the album with the given ID, or returns ErrNotFound if
+// none exists.
+func (s *PostgresStore) Update(ctx context.Context, id string, a *Album) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE albums SET title = $1, artist = $2, price = $3 WHERE id = $4`,
+		a.Title, a.Artist, a.Price, id)
+	if err != nil {
+		return fmt.Errorf("update album: %w", err)
+	}
+	return errIfNoRowsAffected(res)
+}
+
+// Delete removes the album with the given ID, or returns ErrNotFound if
+// none exists.
+func (s *PostgresStore) Delete(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM albums WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete album: %w", err)
+	}
+	return errIfNoRowsAffected(res)
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// SQLiteStore is an AlbumStore backed by a SQLite database.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if necessary) the SQLite database at dsn
+// and ensures the albums table exists.
+func NewSQLiteStore(dsn string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite store: %w", err)
+	}
+
+	const schema = `
+CREATE TABLE IF NOT EXISTS albums (
+	id         TEXT PRIMARY KEY,
+	title      TEXT NOT NULL,
+	artist     TEXT NOT NULL,
+	price      REAL NOT NULL,
+	created_by TEXT NOT NULL DEFAULT ''
+)`
+	if _, err := db.ExecContext(context.Background(), schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create albums table: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// Close closes the underlying database connection.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// Ping reports whether the database is reachable.
+func (s *SQLiteStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// List returns every album in the store.
+func (s *SQLiteStore) List(ctx context.Context) ([]*Album, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, title, artist, price, created_by FROM albums`)
+	if err != nil {
+		return nil, fmt.Errorf("list albums: %w", err)
+	}
+	defer rows.Close()
+
+	var albums []*Album
+	for rows.Next() {
+		a := &Album{}
+		if err := rows.Scan(&a.ID, &a.Title, &a.Artist, &a.Price, &a.CreatedBy); err != nil {
+			return nil, fmt.Errorf("scan album: %w", err)
+		}
+		albums = append(albums, a)
+	}
+	return albums, rows.Err()
+}
+
+// Get returns the album with the given ID, or ErrNotFound if none exists.
+func (s *SQLiteStore) Get(ctx context.Context, id string) (*Album, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT id, title, artist, price, created_by FROM albums WHERE id = ?`, id)
+
+	a := &Album{}
+	if err := row.Scan(&a.ID, &a.Title, &a.Artist, &a.Price, &a.CreatedBy); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get album: %w", err)
+	}
+	return a, nil
+}
+
+// Create adds a new album to the store, or returns ErrConflict if an
+// album with the same ID already exists.
+func (s *SQLiteStore) Create(ctx context.Context, a *Album) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO albums (id, title, artist, price, created_by) VALUES (?, ?, ?, ?, ?)`,
+		a.ID, a.Title, a.Artist, a.Price, a.CreatedBy)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+			return ErrConflict
+		}
+		return fmt.Errorf("create album: %w", err)
+	}
+	return nil
+}
+
+// Update replaces the album with the given ID, or returns ErrNotFound if
+// none exists.
+func (s *SQLiteStore) Update(ctx context.Context, id string, a *Album) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE albums SET title = ?, artist = ?, price = ? WHERE id = ?`,
+		a.Title, a.Artist, a.Price, id)
+	if err != nil {
+		return fmt.Errorf("update album: %w", err)
+	}
+	return errIfNoRowsAffected(res)
+}
+
+// Delete removes the album with the given ID, or returns ErrNotFound if
+// none exists.
+func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM albums WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete album: %w", err)
+	}
+	return errIfNoRowsAffected(res)
+}
+
+// errIfNoRowsAffected returns ErrNotFound if res reports that no rows were
+// affected by the preceding statement.
+func errIfNoRowsAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
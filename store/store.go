@@ -0,0 +1,59 @@
+// Package store defines the persistence abstraction used by the album API
+// and the errors its implementations return.
+package store
+
+import (
+	"context"
+
+	"example/web-service-gin/errs"
+)
+
+// ErrNotFound is returned by an AlbumStore when no album matches the
+// requested ID. It is an alias of errs.ErrNotFound so handlers can
+// compare against a single sentinel regardless of which layer raised it.
+var ErrNotFound = errs.ErrNotFound
+
+// ErrConflict is returned by an AlbumStore's Create when an album with
+// the given ID already exists. It is an alias of errs.ErrConflict so
+// handlers can compare against a single sentinel regardless of which
+// layer raised it.
+var ErrConflict = errs.ErrConflict
+
+// Album represents data about a record album.
+type Album struct {
+	ID     string  `json:"id" example:"48590" description:"Unique identifier for the album."`
+	Title  string  `json:"title" example:"Blue Train" description:"Album title."`
+	Artist string  `json:"artist" example:"John Coltrane" description:"Recording artist."`
+	Price  float32 `json:"price" binding:"min=0" example:"56.99" description:"Retail price in USD."`
+
+	// CreatedBy is the authenticated user who created the album, if
+	// auth middleware was in effect when it was added.
+	CreatedBy string `json:"created_by,omitempty" description:"User who created the album."`
+}
+
+// AlbumStore is the persistence interface required by the album API
+// handlers. Implementations must be safe for concurrent use.
+type AlbumStore interface {
+	// List returns every album in the store.
+	List(ctx context.Context) ([]*Album, error)
+
+	// Get returns the album with the given ID, or ErrNotFound if none
+	// exists.
+	Get(ctx context.Context, id string) (*Album, error)
+
+	// Create adds a new album to the store, or returns ErrConflict if an
+	// album with the same ID already exists.
+	Create(ctx context.Context, a *Album) error
+
+	// Update replaces the album with the given ID, or returns
+	// ErrNotFound if none exists.
+	Update(ctx context.Context, id string, a *Album) error
+
+	// Delete removes the album with the given ID, or returns
+	// ErrNotFound if none exists.
+	Delete(ctx context.Context, id string) error
+
+	// Ping reports whether the store's backend is reachable, for use
+	// by readiness checks.
+	Ping(ctx context.Context) error
+}
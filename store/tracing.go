@@ -0,0 +1,84 @@
+package store
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("example/web-service-gin/store")
+
+// Instrumented wraps next so that each method call opens a span, letting
+// traces started in the HTTP layer propagate through the store.
+func Instrumented(next AlbumStore) AlbumStore {
+	return &instrumentedStore{next: next}
+}
+
+type instrumentedStore struct {
+	next AlbumStore
+}
+
+func (s *instrumentedStore) List(ctx context.Context) ([]*Album, error) {
+	ctx, span := tracer.Start(ctx, "store.List")
+	defer span.End()
+
+	albums, err := s.next.List(ctx)
+	recordErr(span, err)
+	return albums, err
+}
+
+func (s *instrumentedStore) Get(ctx context.Context, id string) (*Album, error) {
+	ctx, span := tracer.Start(ctx, "store.Get", trace.WithAttributes(attribute.String("album.id", id)))
+	defer span.End()
+
+	a, err := s.next.Get(ctx, id)
+	recordErr(span, err)
+	return a, err
+}
+
+func (s *instrumentedStore) Create(ctx context.Context, a *Album) error {
+	ctx, span := tracer.Start(ctx, "store.Create", trace.WithAttributes(attribute.String("album.id", a.ID)))
+	defer span.End()
+
+	err := s.next.Create(ctx, a)
+	recordErr(span, err)
+	return err
+}
+
+func (s *instrumentedStore) Update(ctx context.Context, id string, a *Album) error {
+	ctx, span := tracer.Start(ctx, "store.Update", trace.WithAttributes(attribute.String("album.id", id)))
+	defer span.End()
+
+	err := s.next.Update(ctx, id, a)
+	recordErr(span, err)
+	return err
+}
+
+func (s *instrumentedStore) Delete(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "store.Delete", trace.WithAttributes(attribute.String("album.id", id)))
+	defer span.End()
+
+	err := s.next.Delete(ctx, id)
+	recordErr(span, err)
+	return err
+}
+
+func (s *instrumentedStore) Ping(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "store.Ping")
+	defer span.End()
+
+	err := s.next.Ping(ctx)
+	recordErr(span, err)
+	return err
+}
+
+// recordErr marks span as failed if err is non-nil.
+func recordErr(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
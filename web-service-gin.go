@@ -1,70 +1,257 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"reflect"
 
 	"github.com/gin-gonic/gin"
+
+	"example/web-service-gin/apidoc"
+	"example/web-service-gin/auth"
+	"example/web-service-gin/config"
+	"example/web-service-gin/errs"
+	"example/web-service-gin/obs"
+	"example/web-service-gin/store"
 )
 
-// album represents data about a record album.
-type album struct {
-	ID     string  `json:"id"`
-	Title  string  `json:"title"`
-	Artist string  `json:"artist"`
-	Price  float32 `json:"price"`
-}
+// serviceName identifies this service in traces and logs.
+const serviceName = "album-api"
 
-// albums to seed record album data.
-var albums = []*album{
+// defaultAlbums seeds the in-memory store when no other backend is
+// configured.
+var defaultAlbums = []*store.Album{
 	{ID: "48590", Title: "Blue Train", Artist: "John Coltrane", Price: 56.99},
 	{ID: "48583", Title: "Jeru", Artist: "Gerry Mulligan", Price: 17.99},
 	{ID: "48581", Title: "Sarah Vaughan and Clifford Brown", Artist: "Sarah Vaughan", Price: 39.99},
 }
 
+// server holds the dependencies shared by the album API handlers.
+type server struct {
+	store store.AlbumStore
+}
+
 func main() {
-	router := gin.Default()
-	router.GET("/albums", getAllAlbums)
-	router.GET("/albums/:id", getAlbumByID)
-	router.POST("/albums", addAlbum)
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	authFlag := fs.String("auth", "", "auth mode for mutating routes: none, api_key, or jwt (default none)")
+
+	cfg, err := config.Load(fs, os.Args[1:])
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	rawStore, err := newStore(cfg.StorageBackend, cfg.StorageDSN)
+	if err != nil {
+		log.Fatalf("initialize storage backend: %v", err)
+	}
+	s := store.Instrumented(rawStore)
+
+	authMW, err := newAuthMiddleware(*authFlag)
+	if err != nil {
+		log.Fatalf("initialize auth middleware: %v", err)
+	}
 
-	router.Run(":8080")
+	shutdownTracing, err := obs.InitTracer(serviceName)
+	if err != nil {
+		log.Fatalf("initialize tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	srv := &server{store: s}
+
+	// mutating is the middleware chain applied to routes that modify
+	// album data; GET routes stay public unless an operator adds authMW
+	// to them too.
+	var mutating []gin.HandlerFunc
+	if authMW != nil {
+		mutating = []gin.HandlerFunc{authMW}
+	}
+
+	router := gin.New()
+	router.Use(obs.Tracing(serviceName), obs.Logging(), obs.Metrics(), errs.Recovery())
+	router.GET("/albums", srv.getAllAlbums)
+	router.GET("/albums/:id", srv.getAlbumByID)
+	router.POST("/albums", append(mutating, srv.addAlbum)...)
+	router.PUT("/albums/:id", append(mutating, srv.updateAlbum)...)
+	router.PATCH("/albums/:id", append(mutating, srv.patchAlbum)...)
+	router.DELETE("/albums/:id", append(mutating, srv.deleteAlbum)...)
+
+	router.GET("/metrics", obs.Handler())
+	router.GET("/healthz", obs.Healthz)
+	router.GET("/readyz", obs.Readyz(s))
+
+	albumSchema := apidoc.SchemaFromStruct(reflect.TypeOf(store.Album{}))
+	router.GET("/openapi.json", func(c *gin.Context) {
+		spec := apidoc.BuildSpec(router, "Album API", "1.0", albumSchema)
+		c.JSON(http.StatusOK, spec)
+	})
+	router.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", apidoc.SwaggerUIHTML("/openapi.json"))
+	})
+
+	if err := runServer(cfg, router, rawStore); err != nil {
+		log.Fatalf("run server: %v", err)
+	}
 }
 
-// getAllAlbums returns the list of all albums as JSON.
-func getAllAlbums(c *gin.Context) {
-	c.JSON(http.StatusOK, albums)
+// newStore builds the AlbumStore selected by backend, defaulting to an
+// in-memory store.
+func newStore(backend, dsn string) (store.AlbumStore, error) {
+	switch backend {
+	case "", "memory":
+		return store.NewMemoryStore(defaultAlbums), nil
+	case "sqlite":
+		if dsn == "" {
+			return nil, fmt.Errorf("sqlite storage requires a dsn (set --dsn or ALBUM_STORE_DSN)")
+		}
+		return store.NewSQLiteStore(dsn)
+	case "postgres":
+		if dsn == "" {
+			return nil, fmt.Errorf("postgres storage requires a dsn (set --dsn or ALBUM_STORE_DSN)")
+		}
+		return store.NewPostgresStore(dsn)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", backend)
+	}
+}
+
+// getAllAlbums returns a page of albums matching the query parameters as
+// JSON, wrapped in an envelope that carries the total match count and a
+// cursor for the next page.
+func (s *server) getAllAlbums(c *gin.Context) {
+	var q AlbumQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		errs.Write(c, &errs.ValidationError{Fields: []errs.FieldError{{Field: "query", Detail: err.Error()}}})
+		return
+	}
+
+	albums, err := s.store.List(c.Request.Context())
+	if err != nil {
+		errs.Write(c, err)
+		return
+	}
+
+	matched := filterAlbums(albums, q)
+	sortAlbums(matched, q.Sort)
+	page, nextCursor := paginateAlbums(matched, q)
+
+	c.JSON(http.StatusOK, albumsResponse{
+		Data:       page,
+		NextCursor: nextCursor,
+		Total:      len(matched),
+	})
 }
 
 // addAlbum adds an album from JSON received in the request body.
-func addAlbum(c *gin.Context) {
-	var a album
+func (s *server) addAlbum(c *gin.Context) {
+	var a store.Album
 
 	// Call ShouldBindJSON to confirm that the
 	// request body JSON is valid for the struct.
 	if err := c.ShouldBindJSON(&a); err != nil {
-		c.JSON(http.StatusInternalServerError,
-			gin.H{"error": err.Error()})
+		errs.Write(c, &errs.ValidationError{Fields: []errs.FieldError{{Field: "body", Detail: err.Error()}}})
 		return
 	}
 
-	// Add the new album to the slice.
-	albums = append(albums, &a)
-	// Return the slice as JSON.
-	c.JSON(http.StatusOK, albums)
+	a.CreatedBy = ""
+	if user, ok := auth.CurrentUser(c); ok {
+		a.CreatedBy = user.ID
+	}
+
+	if err := s.store.Create(c.Request.Context(), &a); err != nil {
+		errs.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &a)
 }
 
 // getAlbumByID locates the album whose ID value matches the id
 // parameter sent by the client, then returns that album as a response.
-func getAlbumByID(c *gin.Context) {
+func (s *server) getAlbumByID(c *gin.Context) {
 	id := c.Param("id")
 
-	// Loop through the list of albums, looking for
-	// an album whose ID value matches the parameter.
-	for _, a := range albums {
-		if a.ID == id {
-			c.JSON(http.StatusOK, a)
-			return
-		}
+	a, err := s.store.Get(c.Request.Context(), id)
+	if err != nil {
+		errs.Write(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// updateAlbum replaces the album whose ID matches the id parameter with
+// the album in the request body.
+func (s *server) updateAlbum(c *gin.Context) {
+	id := c.Param("id")
+
+	var a store.Album
+	if err := c.ShouldBindJSON(&a); err != nil {
+		errs.Write(c, &errs.ValidationError{Fields: []errs.FieldError{{Field: "body", Detail: err.Error()}}})
+		return
+	}
+
+	if err := s.store.Update(c.Request.Context(), id, &a); err != nil {
+		errs.Write(c, err)
+		return
+	}
+
+	a.ID = id
+	c.JSON(http.StatusOK, &a)
+}
+
+// albumPatch carries the fields that may be changed by a PATCH request.
+// Fields left nil are unchanged.
+type albumPatch struct {
+	Title  *string  `json:"title"`
+	Artist *string  `json:"artist"`
+	Price  *float32 `json:"price" binding:"omitempty,min=0"`
+}
+
+// patchAlbum applies a partial update to the album whose ID matches the
+// id parameter.
+func (s *server) patchAlbum(c *gin.Context) {
+	id := c.Param("id")
+
+	var p albumPatch
+	if err := c.ShouldBindJSON(&p); err != nil {
+		errs.Write(c, &errs.ValidationError{Fields: []errs.FieldError{{Field: "body", Detail: err.Error()}}})
+		return
+	}
+
+	a, err := s.store.Get(c.Request.Context(), id)
+	if err != nil {
+		errs.Write(c, err)
+		return
+	}
+
+	if p.Title != nil {
+		a.Title = *p.Title
+	}
+	if p.Artist != nil {
+		a.Artist = *p.Artist
+	}
+	if p.Price != nil {
+		a.Price = *p.Price
+	}
+
+	if err := s.store.Update(c.Request.Context(), id, a); err != nil {
+		errs.Write(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// deleteAlbum removes the album whose ID matches the id parameter.
+func (s *server) deleteAlbum(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := s.store.Delete(c.Request.Context(), id); err != nil {
+		errs.Write(c, err)
+		return
 	}
-	c.JSON(http.StatusNotFound, gin.H{"message": "item not found"})
+	c.Status(http.StatusNoContent)
 }